@@ -0,0 +1,27 @@
+// +build darwin,!js
+
+package backend
+
+import "errors"
+
+func init() {
+	Register("avfoundation", newAVFoundation)
+}
+
+// avfoundationBackend will talk to NSSpeechSynthesizer/AVSpeechSynthesizer, to prefer the voices
+// installed with macOS or iOS over espeak-ng. Not yet implemented.
+type avfoundationBackend struct{}
+
+func newAVFoundation() (Backend, error) {
+	return nil, errors.New("backend: avfoundation is not yet implemented; use the espeak backend instead")
+}
+
+func (*avfoundationBackend) Speak(string) error            { return errNotImplemented }
+func (*avfoundationBackend) Stop() error                   { return errNotImplemented }
+func (*avfoundationBackend) SetRate(int) error             { return errNotImplemented }
+func (*avfoundationBackend) SetPitch(int) error            { return errNotImplemented }
+func (*avfoundationBackend) SetVolume(int) error           { return errNotImplemented }
+func (*avfoundationBackend) SetVoice(string) error         { return errNotImplemented }
+func (*avfoundationBackend) ListVoices() ([]string, error) { return nil, errNotImplemented }
+func (*avfoundationBackend) IsSpeaking() bool              { return false }
+func (*avfoundationBackend) Features() Features            { return 0 }
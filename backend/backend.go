@@ -0,0 +1,108 @@
+// Package backend defines a cross-platform text-to-speech Backend interface, so that applications can
+// code against one API and let the build select the best available implementation, falling back to
+// espeak.v2 on platforms without a higher-quality system voice.
+package backend // import "gopkg.in/BenLubar/espeak.v2/backend"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errNotImplemented is returned by placeholder Backend implementations whose platform API is not yet
+// wired up.
+var errNotImplemented = errors.New("backend: not yet implemented")
+
+// Features is a bitmask describing which optional capabilities a Backend supports. Callers should check
+// Features before relying on an optional capability, since unsupported calls are not guaranteed to fail
+// cleanly on every Backend.
+type Features uint32
+
+// Feature bits reported by Backend.Features.
+const (
+	FeatureSSML Features = 1 << iota
+	FeaturePitch
+	FeatureRate
+	FeatureVolume
+	FeatureStop
+	FeatureUtteranceCallbacks
+
+	// FeaturePlayback indicates that Speak plays the synthesized audio itself. Backends without it
+	// only synthesize; callers that need to hear the result must play Speak's output some other way.
+	FeaturePlayback
+)
+
+// Has reports whether f includes every bit set in want.
+func (f Features) Has(want Features) bool {
+	return f&want == want
+}
+
+// Backend is a text-to-speech engine. Implementations are registered with Register under a unique name
+// by build-tagged files, so that a binary only links the backends available on its target platform.
+type Backend interface {
+	// Speak synthesizes text and, if FeaturePlayback is set, plays it back too, returning once
+	// playback has started (whether Speak blocks until playback finishes is backend-specific).
+	// Without FeaturePlayback, Speak only synthesizes: a nil error confirms the text was valid for
+	// this backend's voice, not that anything was played.
+	Speak(text string) error
+
+	// Stop cancels any speech in progress.
+	Stop() error
+
+	// SetRate changes the speed of speech in words per minute, if FeatureRate is supported.
+	SetRate(wpm int) error
+
+	// SetPitch changes the pitch of the voice, if FeaturePitch is supported. The valid range is
+	// backend-specific.
+	SetPitch(pitch int) error
+
+	// SetVolume changes the loudness of the voice as a percentage of the default, if FeatureVolume
+	// is supported.
+	SetVolume(percentage int) error
+
+	// SetVoice selects a voice by name.
+	SetVoice(name string) error
+
+	// ListVoices returns the names of every voice available to this Backend.
+	ListVoices() ([]string, error)
+
+	// IsSpeaking reports whether the Backend is currently playing speech.
+	IsSpeaking() bool
+
+	// Features reports which optional capabilities this Backend supports.
+	Features() Features
+}
+
+// New constructs a new instance of a registered Backend.
+type New func() (Backend, error)
+
+var backends = map[string]New{}
+
+// Register makes a Backend constructor available under name, for later use by Open. It is intended to
+// be called from an init function in a build-tagged file, and panics if name is already registered.
+func Register(name string, newBackend New) {
+	if _, exists := backends[name]; exists {
+		panic(fmt.Sprintf("backend: Register called twice for name %q", name))
+	}
+
+	backends[name] = newBackend
+}
+
+// Names returns the name of every Backend registered in this build, in no particular order.
+func Names() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Open constructs a new instance of the Backend registered under name.
+func Open(name string) (Backend, error) {
+	newBackend, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("backend: no backend registered with name %q", name)
+	}
+
+	return newBackend()
+}
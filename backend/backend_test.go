@@ -0,0 +1,44 @@
+package backend
+
+import "testing"
+
+type fakeBackend struct{}
+
+func (fakeBackend) Speak(string) error            { return nil }
+func (fakeBackend) Stop() error                   { return nil }
+func (fakeBackend) SetRate(int) error             { return nil }
+func (fakeBackend) SetPitch(int) error            { return nil }
+func (fakeBackend) SetVolume(int) error           { return nil }
+func (fakeBackend) SetVoice(string) error         { return nil }
+func (fakeBackend) ListVoices() ([]string, error) { return []string{"test"}, nil }
+func (fakeBackend) IsSpeaking() bool              { return false }
+func (fakeBackend) Features() Features            { return FeatureRate }
+
+func TestRegisterAndOpen(t *testing.T) {
+	Register("fake-test-backend", func() (Backend, error) { return fakeBackend{}, nil })
+
+	b, err := Open("fake-test-backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !b.Features().Has(FeatureRate) {
+		t.Errorf("Features() = %v, want FeatureRate set", b.Features())
+	}
+
+	found := false
+	for _, name := range Names() {
+		if name == "fake-test-backend" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Names() = %v, missing fake-test-backend", Names())
+	}
+}
+
+func TestOpenUnknown(t *testing.T) {
+	if _, err := Open("does-not-exist"); err == nil {
+		t.Error("Open(\"does-not-exist\") returned nil error")
+	}
+}
@@ -0,0 +1,86 @@
+// +build linux
+
+package backend
+
+import (
+	"bytes"
+	"sync/atomic"
+
+	espeak "gopkg.in/BenLubar/espeak.v2"
+)
+
+func init() {
+	Register("espeak", newEspeak)
+}
+
+// espeakBackend adapts an espeak.Context to the Backend interface.
+type espeakBackend struct {
+	ctx espeak.Context
+
+	// speaking is accessed with the atomic package, since Speak may still be running on another
+	// goroutine when IsSpeaking is called, per the Backend interface's documented contract.
+	speaking int32
+}
+
+func newEspeak() (Backend, error) {
+	return &espeakBackend{}, nil
+}
+
+func (b *espeakBackend) Speak(text string) error {
+	atomic.StoreInt32(&b.speaking, 1)
+	defer atomic.StoreInt32(&b.speaking, 0)
+
+	if err := b.ctx.SynthesizeText(text); err != nil {
+		return err
+	}
+
+	// espeakBackend doesn't report FeaturePlayback: espeak.Context has no built-in player, so Speak
+	// renders to a discarded WAV buffer to validate the text and voice. Callers that need to hear
+	// the result should use espeak.Context directly instead of this Backend.
+	var buf bytes.Buffer
+	_, err := b.ctx.EncodeTo(&buf, espeak.AudioConfig{Encoding: espeak.LINEAR16})
+	return err
+}
+
+func (b *espeakBackend) Stop() error {
+	// espeak-ng synthesis is not currently cancelable mid-utterance through this package.
+	return nil
+}
+
+func (b *espeakBackend) SetRate(wpm int) error {
+	b.ctx.SetRate(wpm)
+	return nil
+}
+
+func (b *espeakBackend) SetPitch(pitch int) error {
+	b.ctx.SetPitch(pitch)
+	return nil
+}
+
+func (b *espeakBackend) SetVolume(percentage int) error {
+	b.ctx.SetVolume(percentage)
+	return nil
+}
+
+func (b *espeakBackend) SetVoice(name string) error {
+	return b.ctx.SetVoice(name)
+}
+
+func (b *espeakBackend) ListVoices() ([]string, error) {
+	voices := espeak.ListVoices()
+
+	names := make([]string, len(voices))
+	for i, v := range voices {
+		names[i] = v.Name
+	}
+
+	return names, nil
+}
+
+func (b *espeakBackend) IsSpeaking() bool {
+	return atomic.LoadInt32(&b.speaking) != 0
+}
+
+func (b *espeakBackend) Features() Features {
+	return FeatureSSML | FeaturePitch | FeatureRate | FeatureVolume
+}
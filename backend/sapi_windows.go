@@ -0,0 +1,27 @@
+// +build windows
+
+package backend
+
+import "errors"
+
+func init() {
+	Register("sapi", newSAPI)
+}
+
+// sapiBackend will talk to the Windows Speech API (SAPI) or WinRT's Windows.Media.SpeechSynthesis, to
+// prefer the voices installed with Windows over espeak-ng. Not yet implemented.
+type sapiBackend struct{}
+
+func newSAPI() (Backend, error) {
+	return nil, errors.New("backend: sapi is not yet implemented; use the espeak backend instead")
+}
+
+func (*sapiBackend) Speak(string) error            { return errNotImplemented }
+func (*sapiBackend) Stop() error                   { return errNotImplemented }
+func (*sapiBackend) SetRate(int) error             { return errNotImplemented }
+func (*sapiBackend) SetPitch(int) error            { return errNotImplemented }
+func (*sapiBackend) SetVolume(int) error           { return errNotImplemented }
+func (*sapiBackend) SetVoice(string) error         { return errNotImplemented }
+func (*sapiBackend) ListVoices() ([]string, error) { return nil, errNotImplemented }
+func (*sapiBackend) IsSpeaking() bool              { return false }
+func (*sapiBackend) Features() Features            { return 0 }
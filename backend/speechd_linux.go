@@ -0,0 +1,27 @@
+// +build linux,!js
+
+package backend
+
+import "errors"
+
+func init() {
+	Register("speechd", newSpeechd)
+}
+
+// speechdBackend will talk to speech-dispatcher over its socket protocol, to prefer higher-quality
+// system voices over espeak-ng when they are configured. Not yet implemented.
+type speechdBackend struct{}
+
+func newSpeechd() (Backend, error) {
+	return nil, errors.New("backend: speechd is not yet implemented; use the espeak backend instead")
+}
+
+func (*speechdBackend) Speak(string) error            { return errNotImplemented }
+func (*speechdBackend) Stop() error                   { return errNotImplemented }
+func (*speechdBackend) SetRate(int) error             { return errNotImplemented }
+func (*speechdBackend) SetPitch(int) error            { return errNotImplemented }
+func (*speechdBackend) SetVolume(int) error           { return errNotImplemented }
+func (*speechdBackend) SetVoice(string) error         { return errNotImplemented }
+func (*speechdBackend) ListVoices() ([]string, error) { return nil, errNotImplemented }
+func (*speechdBackend) IsSpeaking() bool              { return false }
+func (*speechdBackend) Features() Features            { return 0 }
@@ -0,0 +1,27 @@
+// +build js
+
+package backend
+
+import "errors"
+
+func init() {
+	Register("webspeech", newWebSpeech)
+}
+
+// webSpeechBackend will talk to the browser's Web Speech API (SpeechSynthesis), to prefer the voices
+// installed in the user's browser over the espeak-ng WebAssembly build. Not yet implemented.
+type webSpeechBackend struct{}
+
+func newWebSpeech() (Backend, error) {
+	return nil, errors.New("backend: webspeech is not yet implemented; use the espeak backend instead")
+}
+
+func (*webSpeechBackend) Speak(string) error            { return errNotImplemented }
+func (*webSpeechBackend) Stop() error                   { return errNotImplemented }
+func (*webSpeechBackend) SetRate(int) error             { return errNotImplemented }
+func (*webSpeechBackend) SetPitch(int) error            { return errNotImplemented }
+func (*webSpeechBackend) SetVolume(int) error           { return errNotImplemented }
+func (*webSpeechBackend) SetVoice(string) error         { return errNotImplemented }
+func (*webSpeechBackend) ListVoices() ([]string, error) { return nil, errNotImplemented }
+func (*webSpeechBackend) IsSpeaking() bool              { return false }
+func (*webSpeechBackend) Features() Features            { return 0 }
@@ -7,9 +7,13 @@
 package espeak // import "gopkg.in/BenLubar/espeak.v2"
 
 import (
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
+
+	"gopkg.in/BenLubar/espeak.v2/ssml"
 )
 
 // Error is the error type from espeak-ng.
@@ -35,7 +39,7 @@ var lock sync.Mutex
 // is empty with default values for rate, volume, pitch, and tone.
 type Context struct {
 	// Samples is a slice of audio samples in PCM format. Use the WriteTo method on the context to
-	// encode Samples as a wav file.
+	// encode Samples as a wav file, or EncodeTo for other audio encodings.
 	Samples []int16
 	// Events are generated along with Samples and contain information about placement of words and
 	// sentences, which may be useful, for example, when generating real time subtitles.
@@ -57,6 +61,12 @@ type Context struct {
 		variant  uint8
 	}
 
+	// text is the input to the most recent SynthesizeText, Synthesize, or SynthesizeAsync call, kept so that
+	// WordTimings can recover word text without callers needing to pass it again.
+	text string
+
+	phonemeEvents bool
+
 	isInit bool
 }
 
@@ -272,7 +282,9 @@ type SynthEvent struct {
 	// Type of the event.
 	Type SynthEventType
 
-	// TextPosition in characters from the start of the string. Unlike Go indexes, this starts at 1.
+	// TextPosition is a 1-based offset from the start of the string, in bytes rather than characters
+	// despite the name (an espeak-ng quirk). Use Context.WordTimings instead of this field directly
+	// when you need rune offsets into the original input.
 	TextPosition int
 
 	// Length of the word, in characters. (for EventWord)
@@ -286,8 +298,9 @@ type SynthEvent struct {
 	Phoneme string // Phoneme is used for EventPhoneme
 }
 
-// TODO:
-/*
+// Synthesize converts an SSML document to speech. It is equivalent to marshaling speak to XML and
+// passing it to SynthesizeText, except that it also validates that every <mark> in speak produced a
+// matching EventMark in Events; callers relying on marks for timing should check the returned error.
 func (ctx *Context) Synthesize(speak *ssml.Speak) error {
 	ctx.init()
 
@@ -296,9 +309,29 @@ func (ctx *Context) Synthesize(speak *ssml.Speak) error {
 		return err
 	}
 
-	return ctx.synthesize(string(text))
+	if err := ctx.synthesize(string(text)); err != nil {
+		return err
+	}
+
+	return ctx.checkMarks(speak.MarkNames())
+}
+
+func (ctx *Context) checkMarks(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, ev := range ctx.Events {
+		if ev.Type == EventMark {
+			seen[ev.Name] = true
+		}
+	}
+
+	for _, name := range names {
+		if !seen[name] {
+			return fmt.Errorf("espeak: mark %q did not produce an EventMark", name)
+		}
+	}
+
+	return nil
 }
-*/
 
 // SynthesizeText converts the given text to speech.
 //
@@ -313,6 +346,8 @@ func (ctx *Context) synthesize(text string) error {
 	lock.Lock()
 	defer lock.Unlock()
 
+	ctx.text = text
+
 	if err := setRate(ctx.rate); err != nil {
 		return err
 	}
@@ -333,5 +368,9 @@ func (ctx *Context) synthesize(text string) error {
 		return err
 	}
 
+	if err := setPhonemeEvents(ctx.phonemeEvents); err != nil {
+		return err
+	}
+
 	return synthesize(text, ctx)
 }
@@ -0,0 +1,336 @@
+// +build linux
+
+package espeak
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// AudioEncoding selects the container and codec used by Context.EncodeTo.
+type AudioEncoding uint8
+
+// Audio encodings supported by EncodeTo. The set and names mirror the encodings offered by common
+// cloud text-to-speech APIs, so that output from this package can be dropped into the same pipelines.
+const (
+	// LINEAR16 is uncompressed signed 16-bit little-endian PCM in a WAV container.
+	LINEAR16 AudioEncoding = iota
+	// MP3 is MPEG Layer III audio at the encoder's default bitrate.
+	MP3
+	// MP3_64_KBPS is MP3 audio encoded at a constant bitrate of 64 kbps.
+	MP3_64_KBPS
+	// OGG_OPUS is Opus audio in an Ogg container.
+	OGG_OPUS
+	// FLAC is Free Lossless Audio Codec.
+	FLAC
+	// MULAW is 8-bit G.711 mu-law, commonly used for telephony.
+	MULAW
+	// ALAW is 8-bit G.711 A-law, commonly used for telephony.
+	ALAW
+)
+
+// String returns the name of the encoding as used in the AudioEncoding constants.
+func (e AudioEncoding) String() string {
+	switch e {
+	case LINEAR16:
+		return "LINEAR16"
+	case MP3:
+		return "MP3"
+	case MP3_64_KBPS:
+		return "MP3_64_KBPS"
+	case OGG_OPUS:
+		return "OGG_OPUS"
+	case FLAC:
+		return "FLAC"
+	case MULAW:
+		return "MULAW"
+	case ALAW:
+		return "ALAW"
+	default:
+		return fmt.Sprintf("AudioEncoding(%d)", uint8(e))
+	}
+}
+
+// AudioConfig controls how Context.EncodeTo renders Samples into an encoded audio stream. The zero value
+// encodes Samples unmodified as 16-bit LINEAR16 PCM at the rate returned by SampleRate.
+type AudioConfig struct {
+	// Encoding selects the output codec and container.
+	Encoding AudioEncoding
+
+	// SampleRateHertz resamples Samples to the given rate. Zero leaves the rate returned by
+	// SampleRate unchanged.
+	SampleRateHertz int
+
+	// SpeakingRate is a post-hoc multiplier applied to Samples independently of Context.SetRate;
+	// values above 1 shorten the output, values below 1 lengthen it. Zero is treated as 1 (no change).
+	SpeakingRate float64
+
+	// Pitch shifts the output in semitones, independently of Context.SetPitch. It is accepted for
+	// API compatibility with cloud TTS configs but is not yet implemented; non-zero values return
+	// an error from EncodeTo.
+	Pitch float64
+
+	// VolumeGainDb increases or decreases the volume of Samples, in decibels, independently of
+	// Context.SetVolume.
+	VolumeGainDb float64
+
+	// EffectsProfileId names one or more audio effects profiles (such as "telephony-class-application",
+	// "handset-class-device", "headphone-class-device", or "wearable-class-device") describing the
+	// playback hardware, so encoders that support it can apply a matching EQ curve. It is accepted for
+	// API compatibility but is not yet implemented; a non-empty value returns an error from EncodeTo.
+	EffectsProfileId []string
+}
+
+// Encoder renders samples (at the given sample rate) as configured by an AudioEncoding, writing the
+// result to w and returning the number of bytes written.
+type Encoder func(w io.Writer, samples []int16, sampleRate int) (int64, error)
+
+var encoders = map[AudioEncoding]Encoder{}
+
+// RegisterEncoder makes enc available as the Encoder for the given AudioEncoding, for use by
+// Context.EncodeTo. It is intended to be called from an init function in a build-tagged file that
+// wraps a codec library (for example cgo bindings to libmp3lame or libopus), so that applications only
+// pay for the codecs they actually link. RegisterEncoder panics if encoding is already registered.
+func RegisterEncoder(encoding AudioEncoding, enc Encoder) {
+	if _, exists := encoders[encoding]; exists {
+		panic(fmt.Sprintf("espeak: RegisterEncoder called twice for %s", encoding))
+	}
+
+	encoders[encoding] = enc
+}
+
+func init() {
+	RegisterEncoder(LINEAR16, writeWAV)
+	RegisterEncoder(MULAW, writeMulaw)
+	RegisterEncoder(ALAW, writeAlaw)
+	RegisterEncoder(FLAC, writeFLAC)
+}
+
+// EncodeTo renders Samples as configured by cfg and writes the result to w, returning the number of
+// bytes written. Unlike SynthesizeText, EncodeTo does not re-run synthesis; it post-processes whatever
+// is already in Samples.
+//
+// MP3, MP3_64_KBPS, and OGG_OPUS have no Encoder registered by default, since encoding them needs a real codec
+// library that this package does not vendor; build against a package that calls RegisterEncoder for
+// those encodings (or call it yourself) to enable them.
+func (ctx *Context) EncodeTo(w io.Writer, cfg AudioConfig) (int64, error) {
+	if cfg.Pitch != 0 {
+		return 0, errors.New("espeak: AudioConfig.Pitch is not yet implemented")
+	}
+	if len(cfg.EffectsProfileId) != 0 {
+		return 0, errors.New("espeak: AudioConfig.EffectsProfileId is not yet implemented")
+	}
+
+	enc, ok := encoders[cfg.Encoding]
+	if !ok {
+		return 0, fmt.Errorf("espeak: no Encoder registered for %s; see RegisterEncoder", cfg.Encoding)
+	}
+
+	samples := ctx.Samples
+	rate := SampleRate()
+
+	if cfg.SpeakingRate != 0 && cfg.SpeakingRate != 1 {
+		samples = resampleLinear(samples, cfg.SpeakingRate)
+	}
+
+	if cfg.VolumeGainDb != 0 {
+		samples = applyGainDB(samples, cfg.VolumeGainDb)
+	}
+
+	if cfg.SampleRateHertz != 0 && cfg.SampleRateHertz != rate {
+		samples = resampleLinear(samples, float64(rate)/float64(cfg.SampleRateHertz))
+		rate = cfg.SampleRateHertz
+	}
+
+	return enc(w, samples, rate)
+}
+
+// resampleLinear returns samples read back at ratio times the original spacing, using linear
+// interpolation between the two nearest input samples. A ratio above 1 shortens the result.
+func resampleLinear(samples []int16, ratio float64) []int16 {
+	if ratio <= 0 || ratio == 1 || len(samples) == 0 {
+		return samples
+	}
+
+	n := int(float64(len(samples)) / ratio)
+	if n <= 0 {
+		return nil
+	}
+
+	out := make([]int16, n)
+	for i := range out {
+		srcPos := float64(i) * ratio
+		i0 := int(srcPos)
+		if i0 >= len(samples)-1 {
+			out[i] = samples[len(samples)-1]
+			continue
+		}
+
+		frac := srcPos - float64(i0)
+		out[i] = int16(float64(samples[i0])*(1-frac) + float64(samples[i0+1])*frac)
+	}
+
+	return out
+}
+
+// applyGainDB scales samples by the given gain in decibels, clamping to the range of int16.
+func applyGainDB(samples []int16, db float64) []int16 {
+	factor := math.Pow(10, db/20)
+
+	out := make([]int16, len(samples))
+	for i, s := range samples {
+		v := float64(s) * factor
+		switch {
+		case v > math.MaxInt16:
+			v = math.MaxInt16
+		case v < math.MinInt16:
+			v = math.MinInt16
+		}
+		out[i] = int16(v)
+	}
+
+	return out
+}
+
+// writeWAV writes samples as a canonical 16-bit PCM mono WAV file.
+func writeWAV(w io.Writer, samples []int16, sampleRate int) (int64, error) {
+	const bitsPerSample = 16
+	const channels = 1
+
+	dataSize := len(samples) * 2
+
+	var header [44]byte
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(36+dataSize))
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], 1) // PCM
+	binary.LittleEndian.PutUint16(header[22:24], channels)
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(sampleRate*channels*bitsPerSample/8))
+	binary.LittleEndian.PutUint16(header[32:34], channels*bitsPerSample/8)
+	binary.LittleEndian.PutUint16(header[34:36], bitsPerSample)
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(dataSize))
+
+	written, err := w.Write(header[:])
+	n := int64(written)
+	if err != nil {
+		return n, err
+	}
+
+	buf := make([]byte, dataSize)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(buf[i*2:], uint16(s))
+	}
+
+	written, err = w.Write(buf)
+	n += int64(written)
+	return n, err
+}
+
+// writeMulaw writes samples as 8-bit G.711 mu-law, preceded by a minimal WAV header so the sample rate
+// travels with the data.
+func writeMulaw(w io.Writer, samples []int16, sampleRate int) (int64, error) {
+	return writeG711(w, samples, sampleRate, 7, encodeMulawSample)
+}
+
+// writeAlaw writes samples as 8-bit G.711 A-law, preceded by a minimal WAV header so the sample rate
+// travels with the data.
+func writeAlaw(w io.Writer, samples []int16, sampleRate int) (int64, error) {
+	return writeG711(w, samples, sampleRate, 6, encodeAlawSample)
+}
+
+func writeG711(w io.Writer, samples []int16, sampleRate int, formatTag uint16, encode func(int16) byte) (int64, error) {
+	const bitsPerSample = 8
+	const channels = 1
+
+	dataSize := len(samples)
+
+	var header [44]byte
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(36+dataSize))
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], formatTag)
+	binary.LittleEndian.PutUint16(header[22:24], channels)
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(sampleRate*channels*bitsPerSample/8))
+	binary.LittleEndian.PutUint16(header[32:34], channels*bitsPerSample/8)
+	binary.LittleEndian.PutUint16(header[34:36], bitsPerSample)
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(dataSize))
+
+	written, err := w.Write(header[:])
+	n := int64(written)
+	if err != nil {
+		return n, err
+	}
+
+	buf := make([]byte, dataSize)
+	for i, s := range samples {
+		buf[i] = encode(s)
+	}
+
+	written, err = w.Write(buf)
+	n += int64(written)
+	return n, err
+}
+
+// encodeMulawSample encodes one linear PCM sample as G.711 mu-law, per ITU-T G.711.
+func encodeMulawSample(sample int16) byte {
+	const bias = 0x84
+	const clip = 32635
+
+	var sign byte
+	s := int(sample)
+	if s < 0 {
+		sign = 0x80
+		s = -s
+	}
+	if s > clip {
+		s = clip
+	}
+	s += bias
+
+	exponent := 7
+	for mask := 0x4000; s&mask == 0 && exponent > 0; mask >>= 1 {
+		exponent--
+	}
+
+	mantissa := byte((s >> uint(exponent+3)) & 0x0f)
+	return ^(sign | byte(exponent<<4) | mantissa)
+}
+
+// encodeAlawSample encodes one linear PCM sample as G.711 A-law, per ITU-T G.711.
+func encodeAlawSample(sample int16) byte {
+	const clip = 32635
+
+	var sign byte = 0x80
+	s := int(sample)
+	if s < 0 {
+		sign = 0
+		s = -s
+	}
+	if s > clip {
+		s = clip
+	}
+
+	var exponent, mantissa byte
+	if s >= 256 {
+		exponent = 7
+		for mask := 0x4000; s&mask == 0 && exponent > 1; mask >>= 1 {
+			exponent--
+		}
+		mantissa = byte((s >> uint(exponent+3)) & 0x0f)
+	} else {
+		mantissa = byte(s >> 4)
+	}
+
+	return (sign | byte(exponent<<4) | mantissa) ^ 0x55
+}
@@ -0,0 +1,119 @@
+// +build linux
+
+package espeak
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestResampleLinearIdentity(t *testing.T) {
+	samples := []int16{1, 2, 3, 4}
+	if got := resampleLinear(samples, 1); !int16sEqual(got, samples) {
+		t.Errorf("resampleLinear(samples, 1) = %v, want %v unchanged", got, samples)
+	}
+	if got := resampleLinear(samples, 0); !int16sEqual(got, samples) {
+		t.Errorf("resampleLinear(samples, 0) = %v, want %v unchanged", got, samples)
+	}
+}
+
+func TestResampleLinearShortens(t *testing.T) {
+	samples := []int16{0, 100, 200, 300, 400, 500, 600, 700}
+	got := resampleLinear(samples, 2)
+	if len(got) != len(samples)/2 {
+		t.Fatalf("len(resampleLinear(samples, 2)) = %d, want %d", len(got), len(samples)/2)
+	}
+	if got[0] != 0 {
+		t.Errorf("resampleLinear(samples, 2)[0] = %d, want 0", got[0])
+	}
+}
+
+func TestResampleLinearLengthens(t *testing.T) {
+	samples := []int16{0, 1000}
+	got := resampleLinear(samples, 0.5)
+	if len(got) != 4 {
+		t.Fatalf("len(resampleLinear(samples, 0.5)) = %d, want 4", len(got))
+	}
+	if got[0] != 0 || got[len(got)-1] != 1000 {
+		t.Errorf("resampleLinear(samples, 0.5) = %v, want endpoints 0 and 1000", got)
+	}
+}
+
+func TestApplyGainDB(t *testing.T) {
+	samples := []int16{1000, -1000}
+
+	if got := applyGainDB(samples, 0); got[0] != 1000 || got[1] != -1000 {
+		t.Errorf("applyGainDB(samples, 0) = %v, want unchanged", got)
+	}
+
+	doubled := applyGainDB(samples, 6.0206) // +6.0206dB ~= *2
+	if doubled[0] < 1990 || doubled[0] > 2010 {
+		t.Errorf("applyGainDB(samples, 6dB)[0] = %d, want ~2000", doubled[0])
+	}
+
+	clipped := applyGainDB([]int16{32000}, 20) // *10, would overflow int16
+	if clipped[0] != 32767 {
+		t.Errorf("applyGainDB clipped to %d, want 32767", clipped[0])
+	}
+}
+
+func TestEncodeMulawAlawSignBit(t *testing.T) {
+	// mu-law and A-law are lossy, so only the sign bit of each encoded sample is checked; zero is
+	// skipped, since it has no sign to compare.
+	for _, sample := range []int16{0, 1000, -1000, 32767, -32768} {
+		mu := encodeMulawSample(sample)
+		a := encodeAlawSample(sample)
+
+		if sample == 0 {
+			continue
+		}
+
+		// The sign of the encoded byte's high bit should match the sign of the input for both codecs.
+		muNegative := mu&0x80 == 0 // mu-law stores sign inverted (1 = positive)
+		if muNegative != (sample < 0) {
+			t.Errorf("encodeMulawSample(%d) = %#x, sign bit disagrees with input sign", sample, mu)
+		}
+
+		aPositive := a&0x80 != 0
+		if aPositive != (sample >= 0) {
+			t.Errorf("encodeAlawSample(%d) = %#x, sign bit disagrees with input sign", sample, a)
+		}
+	}
+}
+
+func TestWriteWAVHeader(t *testing.T) {
+	var buf bytes.Buffer
+	samples := []int16{1, 2, 3}
+
+	n, err := writeWAV(&buf, samples, 22050)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("writeWAV returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	data := buf.Bytes()
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" || string(data[36:40]) != "data" {
+		t.Fatalf("writeWAV produced malformed header: %q", data[:44])
+	}
+	if rate := binary.LittleEndian.Uint32(data[24:28]); rate != 22050 {
+		t.Errorf("sample rate in header = %d, want 22050", rate)
+	}
+	if len(data) != 44+len(samples)*2 {
+		t.Errorf("len(data) = %d, want %d", len(data), 44+len(samples)*2)
+	}
+}
+
+func int16sEqual(a, b []int16) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
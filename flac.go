@@ -0,0 +1,209 @@
+// +build linux
+
+package espeak
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// flacBlockSize is the number of samples per frame. FLAC frames may be smaller (the last frame in a
+// stream usually is), but never larger than this.
+const flacBlockSize = 4096
+
+// writeFLAC writes samples as a FLAC stream, registered under FLAC by RegisterEncoder. Frames use the
+// VERBATIM subframe type rather than FLAC's usual linear-prediction coding, so the output is a valid,
+// losslessly decodable FLAC file without needing to port FLAC's predictor and Rice coder to Go; it
+// just compresses less than a full encoder would.
+func writeFLAC(w io.Writer, samples []int16, sampleRate int) (int64, error) {
+	var body bytes.Buffer
+
+	minBlock, maxBlock := 0, 0
+	for offset, frameNumber := 0, uint64(0); offset < len(samples); offset, frameNumber = offset+flacBlockSize, frameNumber+1 {
+		end := offset + flacBlockSize
+		if end > len(samples) {
+			end = len(samples)
+		}
+
+		chunk := samples[offset:end]
+		if minBlock == 0 || len(chunk) < minBlock {
+			minBlock = len(chunk)
+		}
+		if len(chunk) > maxBlock {
+			maxBlock = len(chunk)
+		}
+
+		writeFlacFrame(&body, chunk, frameNumber)
+	}
+
+	streamInfo := flacStreamInfo(minBlock, maxBlock, sampleRate, len(samples))
+
+	var written int64
+
+	n, err := w.Write([]byte("fLaC"))
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	blockHeader := []byte{
+		0x80, // last metadata block, type 0 (STREAMINFO)
+		byte(len(streamInfo) >> 16),
+		byte(len(streamInfo) >> 8),
+		byte(len(streamInfo)),
+	}
+	n, err = w.Write(blockHeader)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	n, err = w.Write(streamInfo)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	n, err = w.Write(body.Bytes())
+	written += int64(n)
+	return written, err
+}
+
+// flacStreamInfo builds the 34-byte STREAMINFO metadata block body. The MD5 signature of the unencoded
+// audio is left as all zeroes, which FLAC readers treat as "not computed" rather than a checksum
+// failure.
+func flacStreamInfo(minBlock, maxBlock, sampleRate, totalSamples int) []byte {
+	const channels = 1
+	const bitsPerSample = 16
+
+	buf := make([]byte, 34)
+	binary.BigEndian.PutUint16(buf[0:2], uint16(minBlock))
+	binary.BigEndian.PutUint16(buf[2:4], uint16(maxBlock))
+	// buf[4:10] (min/max frame size) left at 0, meaning "not known".
+
+	var packed uint64
+	packed |= uint64(sampleRate&0xFFFFF) << 44
+	packed |= uint64(channels-1) << 41
+	packed |= uint64(bitsPerSample-1) << 36
+	packed |= uint64(totalSamples) & 0xFFFFFFFFF
+	binary.BigEndian.PutUint64(buf[10:18], packed)
+
+	// buf[18:34] (MD5 signature) left at 0.
+
+	return buf
+}
+
+// writeFlacFrame appends one fixed-blocksize FLAC frame containing a single mono VERBATIM subframe to
+// out.
+func writeFlacFrame(out *bytes.Buffer, chunk []int16, frameNumber uint64) {
+	var frame bytes.Buffer
+
+	// Frame header: 14-bit sync code, reserved bit, fixed-blocksize flag, then 4-bit codes meaning
+	// "16-bit (blocksize-1) follows", "sample rate from STREAMINFO", "1 channel, independent", and
+	// "sample size from STREAMINFO", followed by a reserved bit.
+	var headerWord [4]byte
+	binary.BigEndian.PutUint32(headerWord[:], uint32(0x3FFE)<<18|uint32(7)<<12)
+	frame.Write(headerWord[:])
+
+	frame.Write(flacUTF8Uint(frameNumber))
+
+	var blockSizeField [2]byte
+	binary.BigEndian.PutUint16(blockSizeField[:], uint16(len(chunk)-1))
+	frame.Write(blockSizeField[:])
+
+	frame.WriteByte(flacCRC8(frame.Bytes()))
+
+	// Subframe header: zero padding bit, VERBATIM type (0b000001), no wasted bits.
+	frame.WriteByte(0x02)
+
+	sampleBytes := make([]byte, len(chunk)*2)
+	for i, s := range chunk {
+		binary.BigEndian.PutUint16(sampleBytes[i*2:], uint16(s))
+	}
+	frame.Write(sampleBytes)
+
+	var crc [2]byte
+	binary.BigEndian.PutUint16(crc[:], flacCRC16(frame.Bytes()))
+	frame.Write(crc[:])
+
+	out.Write(frame.Bytes())
+}
+
+// flacUTF8Uint encodes v (up to 31 bits) using the variable-length scheme FLAC uses for frame numbers,
+// which follows the same leading-byte pattern as UTF-8 but is not actually UTF-8.
+func flacUTF8Uint(v uint64) []byte {
+	switch {
+	case v < 0x80:
+		return []byte{byte(v)}
+	case v < 0x800:
+		return []byte{
+			0xC0 | byte(v>>6),
+			0x80 | byte(v)&0x3F,
+		}
+	case v < 0x10000:
+		return []byte{
+			0xE0 | byte(v>>12),
+			0x80 | byte(v>>6)&0x3F,
+			0x80 | byte(v)&0x3F,
+		}
+	case v < 0x200000:
+		return []byte{
+			0xF0 | byte(v>>18),
+			0x80 | byte(v>>12)&0x3F,
+			0x80 | byte(v>>6)&0x3F,
+			0x80 | byte(v)&0x3F,
+		}
+	case v < 0x4000000:
+		return []byte{
+			0xF8 | byte(v>>24),
+			0x80 | byte(v>>18)&0x3F,
+			0x80 | byte(v>>12)&0x3F,
+			0x80 | byte(v>>6)&0x3F,
+			0x80 | byte(v)&0x3F,
+		}
+	default:
+		return []byte{
+			0xFC | byte(v>>30),
+			0x80 | byte(v>>24)&0x3F,
+			0x80 | byte(v>>18)&0x3F,
+			0x80 | byte(v>>12)&0x3F,
+			0x80 | byte(v>>6)&0x3F,
+			0x80 | byte(v)&0x3F,
+		}
+	}
+}
+
+// flacCRC8 computes the CRC-8 (polynomial x^8+x^2+x^1+1, initialized to 0) FLAC uses for its frame
+// header checksum.
+func flacCRC8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc ^= b
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// flacCRC16 computes the CRC-16 (polynomial x^16+x^15+x^2+1, initialized to 0) FLAC uses for its frame
+// footer checksum.
+func flacCRC16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
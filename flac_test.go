@@ -0,0 +1,125 @@
+// +build linux
+
+package espeak
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestWriteFLACStructure(t *testing.T) {
+	samples := make([]int16, flacBlockSize+10)
+	for i := range samples {
+		samples[i] = int16(i)
+	}
+
+	var buf bytes.Buffer
+	n, err := writeFLAC(&buf, samples, 22050)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("writeFLAC returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	data := buf.Bytes()
+	if string(data[0:4]) != "fLaC" {
+		t.Fatalf("missing fLaC marker, got %q", data[0:4])
+	}
+
+	if data[4] != 0x80 {
+		t.Errorf("STREAMINFO block header = %#x, want last-block flag set and type 0", data[4])
+	}
+
+	blockLen := int(data[5])<<16 | int(data[6])<<8 | int(data[7])
+	if blockLen != 34 {
+		t.Errorf("STREAMINFO length = %d, want 34", blockLen)
+	}
+
+	info := data[8 : 8+34]
+	minBlock := binary.BigEndian.Uint16(info[0:2])
+	maxBlock := binary.BigEndian.Uint16(info[2:4])
+	if minBlock != 10 {
+		t.Errorf("min block size = %d, want 10 (the short final frame)", minBlock)
+	}
+	if maxBlock != flacBlockSize {
+		t.Errorf("max block size = %d, want %d", maxBlock, flacBlockSize)
+	}
+
+	packed := binary.BigEndian.Uint64(info[10:18])
+	sampleRate := uint32(packed >> 44)
+	channels := uint8((packed>>41)&0x7) + 1
+	bps := uint8((packed>>36)&0x1F) + 1
+	totalSamples := packed & 0xFFFFFFFFF
+	if sampleRate != 22050 {
+		t.Errorf("sample rate = %d, want 22050", sampleRate)
+	}
+	if channels != 1 {
+		t.Errorf("channels = %d, want 1", channels)
+	}
+	if bps != 16 {
+		t.Errorf("bits per sample = %d, want 16", bps)
+	}
+	if int(totalSamples) != len(samples) {
+		t.Errorf("total samples = %d, want %d", totalSamples, len(samples))
+	}
+}
+
+func TestFlacUTF8UintRoundTrip(t *testing.T) {
+	for _, v := range []uint64{0, 1, 0x7F, 0x80, 0x7FF, 0x800, 0xFFFF, 0x10000, 0x1FFFFF} {
+		encoded := flacUTF8Uint(v)
+		decoded, n := decodeFlacUTF8Uint(encoded)
+		if n != len(encoded) || decoded != v {
+			t.Errorf("flacUTF8Uint(%d) round-trip = %d (consumed %d of %d bytes)", v, decoded, n, len(encoded))
+		}
+	}
+}
+
+func TestFlacCRC(t *testing.T) {
+	if got := flacCRC8(nil); got != 0 {
+		t.Errorf("flacCRC8(nil) = %#x, want 0", got)
+	}
+	if got := flacCRC16(nil); got != 0 {
+		t.Errorf("flacCRC16(nil) = %#x, want 0", got)
+	}
+
+	a := flacCRC8([]byte("hello"))
+	b := flacCRC8([]byte("hellp"))
+	if a == b {
+		t.Error("flacCRC8 produced the same checksum for different input")
+	}
+}
+
+// decodeFlacUTF8Uint decodes the scheme produced by flacUTF8Uint, for use by
+// TestFlacUTF8UintRoundTrip.
+func decodeFlacUTF8Uint(b []byte) (uint64, int) {
+	if len(b) == 0 {
+		return 0, 0
+	}
+
+	first := b[0]
+	var v uint64
+	var extra int
+
+	switch {
+	case first&0x80 == 0:
+		return uint64(first), 1
+	case first&0xE0 == 0xC0:
+		v, extra = uint64(first&0x1F), 1
+	case first&0xF0 == 0xE0:
+		v, extra = uint64(first&0x0F), 2
+	case first&0xF8 == 0xF0:
+		v, extra = uint64(first&0x07), 3
+	case first&0xFC == 0xF8:
+		v, extra = uint64(first&0x03), 4
+	default:
+		v, extra = uint64(first&0x01), 5
+	}
+
+	for i := 0; i < extra; i++ {
+		v = v<<6 | uint64(b[1+i]&0x3F)
+	}
+
+	return v, 1 + extra
+}
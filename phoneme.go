@@ -0,0 +1,48 @@
+// +build linux
+
+package espeak
+
+// EnablePhonemeEvents toggles whether synthesis populates EventPhoneme entries in Events, giving
+// phoneme-level timing alongside EventWord and EventSentence. It is disabled by default, since
+// generating per-phoneme events adds overhead that most callers don't need.
+func (ctx *Context) EnablePhonemeEvents(enable bool) {
+	ctx.init()
+
+	ctx.phonemeEvents = enable
+}
+
+// PhonemeAlphabet selects the transcription notation used by Phonemize. EnablePhonemeEvents takes no
+// PhonemeAlphabet, so it does not select the notation of EventPhoneme entries.
+type PhonemeAlphabet uint8
+
+// Phoneme alphabets supported by Phonemize.
+const (
+	// PhonemeIPA is the International Phonetic Alphabet.
+	PhonemeIPA PhonemeAlphabet = iota
+
+	// PhonemeKirshenbaum is espeak-ng's ASCII-safe phoneme notation derived from Kirshenbaum's
+	// scheme, useful where IPA's non-ASCII characters are inconvenient.
+	PhonemeKirshenbaum
+
+	// PhonemeASCII is espeak-ng's internal ASCII phoneme mnemonics, as used in its .dict source
+	// files.
+	PhonemeASCII
+)
+
+// Phonemize converts text to its phonetic transcription in the given voice and alphabet, without
+// generating audio. This is useful for lipsync/animation and linguistics tooling that only needs
+// grapheme-to-phoneme conversion and has no use for synthesized speech.
+//
+// If voice is empty, the currently selected default voice is used.
+func Phonemize(text, voice string, alphabet PhonemeAlphabet) (string, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if voice != "" {
+		if err := setVoice(voice, "", Unknown, 0, 0); err != nil {
+			return "", err
+		}
+	}
+
+	return textToPhonemes(text, alphabet)
+}
@@ -0,0 +1,183 @@
+// +build linux
+
+package espeak
+
+import (
+	"runtime"
+	"sync"
+)
+
+// poolInstance is a single espeak-ng library instance bound to one locked OS thread, independent of
+// the global lock used by SynthesizeText. The concrete implementation is nativeInstance; poolInstance
+// exists as a separate interface so tests can substitute a fake one without touching native code.
+type poolInstance interface {
+	synthesize(text string, ctx *Context) error
+	close()
+}
+
+// newPoolInstance creates a poolInstance bound to the calling OS thread. It is a variable so tests can
+// substitute a fake implementation.
+var newPoolInstance = newInstance
+
+// instanceHandle is the native per-thread espeak-ng library state behind a nativeInstance: a full
+// library instance of its own, as opposed to the single global one guarded by lock. Implemented in the
+// native, per-platform files alongside setRate, listVoices, and this package's other hooks into
+// espeak-ng.
+type instanceHandle interface {
+	setRate(wpm int) error
+	setVolume(percentage int) error
+	setPitch(pitch int) error
+	setTone(tone int) error
+	setVoice(name, language string, gender Gender, age, variant uint8) error
+	setPhonemeEvents(enable bool) error
+	synthesize(text string, ctx *Context) error
+	close()
+}
+
+// newInstanceHandle creates an instanceHandle bound to the calling OS thread, with its own espeak-ng
+// library state independent of every other instanceHandle and of the global state behind lock.
+func newInstanceHandle() (instanceHandle, error)
+
+// nativeInstance is the poolInstance that backs newInstance: it applies a Context's settings to its own
+// instanceHandle and synthesizes against that handle instead of the shared global state, so that
+// multiple nativeInstances can run truly concurrently.
+type nativeInstance struct {
+	handle instanceHandle
+}
+
+func newInstance() (poolInstance, error) {
+	handle, err := newInstanceHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	return &nativeInstance{handle: handle}, nil
+}
+
+func (inst *nativeInstance) synthesize(text string, ctx *Context) error {
+	if err := inst.handle.setRate(ctx.rate); err != nil {
+		return err
+	}
+	if err := inst.handle.setVolume(ctx.volume); err != nil {
+		return err
+	}
+	if err := inst.handle.setPitch(ctx.pitch); err != nil {
+		return err
+	}
+	if err := inst.handle.setTone(ctx.tone); err != nil {
+		return err
+	}
+	if err := inst.handle.setVoice(ctx.voice.name, ctx.voice.language, ctx.voice.gender, ctx.voice.age, ctx.voice.variant); err != nil {
+		return err
+	}
+	if err := inst.handle.setPhonemeEvents(ctx.phonemeEvents); err != nil {
+		return err
+	}
+
+	return inst.handle.synthesize(text, ctx)
+}
+
+func (inst *nativeInstance) close() {
+	inst.handle.close()
+}
+
+type poolJob struct {
+	ctx  *Context
+	text string
+	done chan<- error
+}
+
+// workerPool is a fixed-size set of goroutines, each pinned to an OS thread with its own poolInstance,
+// pulling jobs from a shared queue. This is what lets SynthesizeAsync use multiple cores instead of
+// bottlenecking on the lock that guards SynthesizeText.
+type workerPool struct {
+	mu   sync.Mutex
+	jobs chan poolJob
+	stop []chan struct{}
+}
+
+var defaultPool = &workerPool{jobs: make(chan poolJob)}
+
+func init() {
+	defaultPool.setSize(1)
+}
+
+// setSize replaces the running workers with a fresh set of n, each starting a new poolInstance via
+// newPoolInstance. Workers are always rebuilt from scratch, rather than merely topped up or trimmed, so
+// that a worker started before a test (or a future caller) swaps newPoolInstance never lingers on with
+// whatever instance it was handed at creation.
+func (p *workerPool) setSize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, stop := range p.stop {
+		close(stop)
+	}
+
+	p.stop = make([]chan struct{}, 0, n)
+	for i := 0; i < n; i++ {
+		stop := make(chan struct{})
+		p.stop = append(p.stop, stop)
+		go runPoolWorker(p.jobs, stop)
+	}
+}
+
+func runPoolWorker(jobs <-chan poolJob, stop <-chan struct{}) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	inst, err := newPoolInstance()
+	if err != nil {
+		// Keep draining jobs with the error instead of leaving callers blocked forever.
+		for {
+			select {
+			case job := <-jobs:
+				job.done <- err
+				close(job.done)
+			case <-stop:
+				return
+			}
+		}
+	}
+	defer inst.close()
+
+	for {
+		select {
+		case job := <-jobs:
+			job.done <- inst.synthesize(job.text, job.ctx)
+			close(job.done)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SetParallelism sets the number of goroutine-pinned espeak-ng instances backing SynthesizeAsync, so
+// that batch TTS work (for example rendering audio for many chat lines) can run on multiple cores
+// instead of bottlenecking on the single lock used by SynthesizeText. n must be at least 1.
+func SetParallelism(n int) {
+	if n < 1 {
+		panic("espeak: SetParallelism: n must be at least 1")
+	}
+
+	defaultPool.setSize(n)
+}
+
+// SynthesizeAsync converts text to speech without blocking the caller, running on the worker pool
+// sized by SetParallelism. It returns a channel that receives the error result (nil on success) once
+// synthesis completes; the channel is closed after the single value is sent.
+func (ctx *Context) SynthesizeAsync(text string) <-chan error {
+	ctx.init()
+
+	// Set here, rather than left to the worker, so WordTimings sees it immediately even if the job
+	// hasn't reached the front of the queue yet; matches synthesize's ctx.text = text in doc.go.
+	ctx.text = text
+
+	done := make(chan error, 1)
+
+	go func() {
+		defaultPool.jobs <- poolJob{ctx: ctx, text: text, done: done}
+	}()
+
+	return done
+}
@@ -0,0 +1,122 @@
+// +build linux
+
+package espeak
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakePoolInstance struct {
+	calls *int32
+}
+
+func (f fakePoolInstance) synthesize(text string, ctx *Context) error {
+	atomic.AddInt32(f.calls, 1)
+	if text == "fail" {
+		return errTestSynthesize
+	}
+	return nil
+}
+
+func (fakePoolInstance) close() {}
+
+var errTestSynthesize = &Error{Message: "test failure"}
+
+func withFakePool(t *testing.T, calls *int32) {
+	t.Helper()
+
+	prev := newPoolInstance
+	newPoolInstance = func() (poolInstance, error) {
+		return fakePoolInstance{calls: calls}, nil
+	}
+	t.Cleanup(func() {
+		newPoolInstance = prev
+		defaultPool.setSize(1)
+	})
+}
+
+func TestSetParallelismRejectsInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("SetParallelism(0) did not panic")
+		}
+	}()
+
+	SetParallelism(0)
+}
+
+func TestSetParallelismResizesPool(t *testing.T) {
+	var calls int32
+	withFakePool(t, &calls)
+
+	SetParallelism(4)
+	defaultPool.mu.Lock()
+	n := len(defaultPool.stop)
+	defaultPool.mu.Unlock()
+	if n != 4 {
+		t.Errorf("len(defaultPool.stop) = %d, want 4", n)
+	}
+
+	SetParallelism(2)
+	defaultPool.mu.Lock()
+	n = len(defaultPool.stop)
+	defaultPool.mu.Unlock()
+	if n != 2 {
+		t.Errorf("len(defaultPool.stop) = %d, want 2", n)
+	}
+}
+
+func TestSynthesizeAsync(t *testing.T) {
+	var calls int32
+	withFakePool(t, &calls)
+	SetParallelism(2)
+
+	ctx := &Context{}
+	if err := <-ctx.SynthesizeAsync("hello"); err != nil {
+		t.Errorf("SynthesizeAsync(\"hello\") = %v, want nil", err)
+	}
+	if err := <-ctx.SynthesizeAsync("fail"); err != errTestSynthesize {
+		t.Errorf("SynthesizeAsync(\"fail\") = %v, want %v", err, errTestSynthesize)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("synthesize called %d times, want 2", got)
+	}
+}
+
+func TestSynthesizeAsyncConcurrent(t *testing.T) {
+	var calls int32
+	withFakePool(t, &calls)
+	SetParallelism(4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each goroutine uses its own Context, per Context's documented single-goroutine
+			// contract; sharing one across goroutines would race on ctx.init().
+			ctx := &Context{}
+			<-ctx.SynthesizeAsync("hello")
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("SynthesizeAsync calls did not all complete")
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 8 {
+		t.Errorf("synthesize called %d times, want 8", got)
+	}
+}
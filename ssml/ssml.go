@@ -0,0 +1,276 @@
+// Package ssml provides typed Go structs for building Speech Synthesis Markup Language (SSML)
+// documents, for use with espeak.Context.Synthesize instead of hand-concatenating XML strings.
+package ssml // import "gopkg.in/BenLubar/espeak.v2/ssml"
+
+import "encoding/xml"
+
+// Content is anything that may appear inside a Speak, Voice, Prosody, Emphasis, P, or S element.
+type Content interface {
+	isContent()
+}
+
+// Text is plain character data within an element.
+type Text string
+
+func (Text) isContent() {}
+
+// MarshalXML writes t as character data, without an enclosing element.
+func (t Text) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return e.EncodeToken(xml.CharData(t))
+}
+
+// Break is a pause in speech. Time is a duration such as "500ms" or "2s"; Strength, if Time is empty,
+// is one of "none", "x-weak", "weak", "medium", "strong", or "x-strong".
+type Break struct {
+	XMLName  xml.Name `xml:"break"`
+	Time     string   `xml:"time,attr,omitempty"`
+	Strength string   `xml:"strength,attr,omitempty"`
+}
+
+func (*Break) isContent() {}
+
+// NewBreak returns a <break> of the given duration, such as "500ms" or "2s".
+func NewBreak(time string) *Break {
+	return &Break{Time: time}
+}
+
+// Mark is a named point in the document. After synthesis, espeak.Context.Events contains a matching
+// EventMark for every Mark that was reached.
+type Mark struct {
+	XMLName xml.Name `xml:"mark"`
+	Name    string   `xml:"name,attr"`
+}
+
+func (*Mark) isContent() {}
+
+// NewMark returns a <mark> with the given name.
+func NewMark(name string) *Mark {
+	return &Mark{Name: name}
+}
+
+// Audio plays an external audio file in place of synthesized speech.
+type Audio struct {
+	XMLName xml.Name `xml:"audio"`
+	Src     string   `xml:"src,attr"`
+}
+
+func (*Audio) isContent() {}
+
+// NewAudio returns an <audio> element referencing src.
+func NewAudio(src string) *Audio {
+	return &Audio{Src: src}
+}
+
+// Phoneme replaces the pronunciation of Text with the phonetic transcription Ph, written in Alphabet
+// (for example "ipa" or "x-sampa").
+type Phoneme struct {
+	XMLName  xml.Name `xml:"phoneme"`
+	Alphabet string   `xml:"alphabet,attr,omitempty"`
+	Ph       string   `xml:"ph,attr"`
+	Text     string   `xml:",chardata"`
+}
+
+func (*Phoneme) isContent() {}
+
+// NewPhoneme returns a <phoneme> that pronounces text as ph, transcribed in alphabet.
+func NewPhoneme(text, alphabet, ph string) *Phoneme {
+	return &Phoneme{Alphabet: alphabet, Ph: ph, Text: text}
+}
+
+// SayAs interprets Text according to InterpretAs (for example "cardinal", "ordinal", "characters",
+// "date", or "telephone"), optionally refined by Format.
+type SayAs struct {
+	XMLName     xml.Name `xml:"say-as"`
+	InterpretAs string   `xml:"interpret-as,attr"`
+	Format      string   `xml:"format,attr,omitempty"`
+	Text        string   `xml:",chardata"`
+}
+
+func (*SayAs) isContent() {}
+
+// NewSayAs returns a <say-as> that interprets text according to interpretAs.
+func NewSayAs(text, interpretAs string) *SayAs {
+	return &SayAs{InterpretAs: interpretAs, Text: text}
+}
+
+// Sub substitutes Alias for Text when determining pronunciation, while leaving Text as the written form.
+type Sub struct {
+	XMLName xml.Name `xml:"sub"`
+	Alias   string   `xml:"alias,attr"`
+	Text    string   `xml:",chardata"`
+}
+
+func (*Sub) isContent() {}
+
+// NewSub returns a <sub> that pronounces text as alias.
+func NewSub(text, alias string) *Sub {
+	return &Sub{Alias: alias, Text: text}
+}
+
+// Voice switches the voice used for its content, by any combination of Name, Languages, Gender, Age,
+// and Variant. Empty fields are unconstrained.
+type Voice struct {
+	XMLName   xml.Name `xml:"voice"`
+	Name      string   `xml:"name,attr,omitempty"`
+	Languages string   `xml:"languages,attr,omitempty"`
+	Gender    string   `xml:"gender,attr,omitempty"`
+	Age       uint8    `xml:"age,attr,omitempty"`
+	Variant   uint8    `xml:"variant,attr,omitempty"`
+	Content   []Content
+}
+
+func (*Voice) isContent() {}
+
+// NewVoice returns a <voice> selecting the voice named name, containing content.
+func NewVoice(name string, content ...Content) *Voice {
+	return &Voice{Name: name, Content: content}
+}
+
+// Add appends content to v and returns v, for chaining.
+func (v *Voice) Add(content ...Content) *Voice {
+	v.Content = append(v.Content, content...)
+	return v
+}
+
+// Prosody adjusts the Rate, Pitch, and Volume of its content. Each is a free-form SSML value, such as
+// a percentage ("120%"), a signed relative change ("+2st", "-10%"), or a keyword ("slow", "loud").
+type Prosody struct {
+	XMLName xml.Name `xml:"prosody"`
+	Rate    string   `xml:"rate,attr,omitempty"`
+	Pitch   string   `xml:"pitch,attr,omitempty"`
+	Volume  string   `xml:"volume,attr,omitempty"`
+	Content []Content
+}
+
+func (*Prosody) isContent() {}
+
+// NewProsody returns a <prosody> wrapping content.
+func NewProsody(content ...Content) *Prosody {
+	return &Prosody{Content: content}
+}
+
+// Add appends content to p and returns p, for chaining.
+func (p *Prosody) Add(content ...Content) *Prosody {
+	p.Content = append(p.Content, content...)
+	return p
+}
+
+// WithRate sets Rate and returns p, for chaining.
+func (p *Prosody) WithRate(rate string) *Prosody {
+	p.Rate = rate
+	return p
+}
+
+// WithPitch sets Pitch and returns p, for chaining.
+func (p *Prosody) WithPitch(pitch string) *Prosody {
+	p.Pitch = pitch
+	return p
+}
+
+// WithVolume sets Volume and returns p, for chaining.
+func (p *Prosody) WithVolume(volume string) *Prosody {
+	p.Volume = volume
+	return p
+}
+
+// Emphasis stresses its content at the given Level ("strong", "moderate", or "reduced").
+type Emphasis struct {
+	XMLName xml.Name `xml:"emphasis"`
+	Level   string   `xml:"level,attr,omitempty"`
+	Content []Content
+}
+
+func (*Emphasis) isContent() {}
+
+// NewEmphasis returns an <emphasis> at the given level, wrapping content.
+func NewEmphasis(level string, content ...Content) *Emphasis {
+	return &Emphasis{Level: level, Content: content}
+}
+
+// Add appends content to em and returns em, for chaining.
+func (em *Emphasis) Add(content ...Content) *Emphasis {
+	em.Content = append(em.Content, content...)
+	return em
+}
+
+// P is a paragraph.
+type P struct {
+	XMLName xml.Name `xml:"p"`
+	Content []Content
+}
+
+func (*P) isContent() {}
+
+// NewP returns a <p> wrapping content.
+func NewP(content ...Content) *P {
+	return &P{Content: content}
+}
+
+// Add appends content to p and returns p, for chaining.
+func (p *P) Add(content ...Content) *P {
+	p.Content = append(p.Content, content...)
+	return p
+}
+
+// S is a sentence.
+type S struct {
+	XMLName xml.Name `xml:"s"`
+	Content []Content
+}
+
+func (*S) isContent() {}
+
+// NewS returns an <s> wrapping content.
+func NewS(content ...Content) *S {
+	return &S{Content: content}
+}
+
+// Add appends content to s and returns s, for chaining.
+func (s *S) Add(content ...Content) *S {
+	s.Content = append(s.Content, content...)
+	return s
+}
+
+// Speak is the root element of an SSML document.
+type Speak struct {
+	XMLName xml.Name `xml:"speak"`
+	Content []Content
+}
+
+// NewSpeak returns a <speak> document wrapping content.
+func NewSpeak(content ...Content) *Speak {
+	return &Speak{Content: content}
+}
+
+// Add appends content to s and returns s, for chaining.
+func (s *Speak) Add(content ...Content) *Speak {
+	s.Content = append(s.Content, content...)
+	return s
+}
+
+// MarkNames returns the name of every Mark in the document, in document order, including marks nested
+// inside Voice, Prosody, Emphasis, P, and S elements.
+func (s *Speak) MarkNames() []string {
+	var names []string
+	walkMarks(s.Content, &names)
+	return names
+}
+
+func walkMarks(content []Content, names *[]string) {
+	for _, c := range content {
+		switch v := c.(type) {
+		case *Mark:
+			*names = append(*names, v.Name)
+		case *Voice:
+			walkMarks(v.Content, names)
+		case *Prosody:
+			walkMarks(v.Content, names)
+		case *Emphasis:
+			walkMarks(v.Content, names)
+		case *P:
+			walkMarks(v.Content, names)
+		case *S:
+			walkMarks(v.Content, names)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ssml
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestMarshalMixedContent(t *testing.T) {
+	speak := NewSpeak(
+		Text("Hello, "),
+		NewEmphasis("strong", Text("world")),
+		Text("! "),
+		NewMark("end"),
+	)
+
+	out, err := xml.Marshal(speak)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(out)
+	want := `<speak>Hello, <emphasis level="strong">world</emphasis>! <mark name="end"></mark></speak>`
+	if got != want {
+		t.Errorf("Marshal() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkNames(t *testing.T) {
+	speak := NewSpeak(
+		NewMark("a"),
+		NewVoice("default", NewMark("b"), NewP(NewS(NewMark("c")))),
+	)
+
+	got := speak.MarkNames()
+	want := []string{"a", "b", "c"}
+
+	if len(got) != len(want) {
+		t.Fatalf("MarkNames() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MarkNames() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPhonemeAttributes(t *testing.T) {
+	p := NewPhoneme("tomato", "ipa", "təˈmeɪtoʊ")
+
+	out, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(out), `ph="təˈmeɪtoʊ"`) {
+		t.Errorf("Marshal() = %q, missing ph attribute", out)
+	}
+}
@@ -0,0 +1,68 @@
+// +build linux
+
+package espeak
+
+import (
+	"context"
+)
+
+// SynthChunk is one piece of a streamed synthesis produced by Context.SynthesizeStream.
+type SynthChunk struct {
+	// Samples is the audio produced since the previous chunk, in the same format as Context.Samples.
+	Samples []int16
+
+	// Events holds any SynthEvents generated since the previous chunk.
+	Events []*SynthEvent
+}
+
+// SynthesizeStream converts text to speech incrementally, delivering audio as espeak-ng produces it
+// instead of buffering the whole utterance into Context.Samples. This is intended for realtime use
+// cases such as low-latency playback or live subtitles, where waiting for SynthesizeText to return
+// would add unacceptable latency.
+//
+// The returned channel is closed once synthesis finishes or c is canceled. If c is canceled before
+// synthesis completes, synthesis is aborted and the channel is closed without further chunks; callers
+// that need to distinguish abort from completion should check c.Err() after the channel closes.
+//
+// Unlike SynthesizeText, SynthesizeStream does not populate Context.Samples or Context.Events; callers
+// that need the complete output should accumulate the chunks themselves.
+func (ctx *Context) SynthesizeStream(c context.Context, text string) (<-chan SynthChunk, error) {
+	ctx.init()
+
+	out := make(chan SynthChunk)
+
+	lock.Lock()
+	if err := setRate(ctx.rate); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	if err := setVolume(ctx.volume); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	if err := setPitch(ctx.pitch); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	if err := setTone(ctx.tone); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	if err := setVoice(ctx.voice.name, ctx.voice.language, ctx.voice.gender, ctx.voice.age, ctx.voice.variant); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	if err := setPhonemeEvents(ctx.phonemeEvents); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	go func() {
+		defer lock.Unlock()
+		defer close(out)
+
+		synthesizeStream(text, ctx, out, c.Done())
+	}()
+
+	return out, nil
+}
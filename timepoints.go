@@ -0,0 +1,109 @@
+// +build linux
+
+package espeak
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// Timepoint is the position of a named SSML <mark> within the synthesized audio, matching the
+// SSML_MARK timepoint concept exposed by cloud text-to-speech APIs.
+type Timepoint struct {
+	MarkName    string
+	TimeSeconds float64
+}
+
+// Timepoints returns a Timepoint for every EventMark in Events, in the order they occurred.
+func (ctx *Context) Timepoints() []Timepoint {
+	var points []Timepoint
+
+	for _, ev := range ctx.Events {
+		if ev.Type == EventMark {
+			points = append(points, Timepoint{
+				MarkName:    ev.Name,
+				TimeSeconds: ev.AudioPosition.Seconds(),
+			})
+		}
+	}
+
+	return points
+}
+
+// WordTiming gives the position within the synthesized audio of one word, along with the word's text
+// as it appears in the original input.
+type WordTiming struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// WordTimings returns a WordTiming for every EventWord in Events, built from the following EventEnd (or
+// other boundary event) for End, and with Text decoded back to rune offsets into the text most recently
+// passed to SynthesizeText, Synthesize, or SynthesizeAsync, rather than the byte positions espeak-ng reports in
+// SynthEvent.TextPosition. This saves subtitle and karaoke callers from reimplementing UTF-8 index
+// arithmetic themselves.
+func (ctx *Context) WordTimings() []WordTiming {
+	runes := []rune(ctx.text)
+
+	var timings []WordTiming
+
+	for i, ev := range ctx.Events {
+		if ev.Type != EventWord {
+			continue
+		}
+
+		start := clampRuneIndex(runeIndex(ctx.text, ev.TextPosition-1), len(runes))
+		end := clampRuneIndex(start+ev.Length, len(runes))
+
+		wt := WordTiming{
+			Start: ev.AudioPosition,
+			End:   ev.AudioPosition,
+			Text:  string(runes[start:end]),
+		}
+
+		if end, ok := nextBoundary(ctx.Events, i); ok {
+			wt.End = end
+		}
+
+		timings = append(timings, wt)
+	}
+
+	return timings
+}
+
+// nextBoundary returns the AudioPosition of the first event after index from that marks the end of a
+// word, sentence, or message, which is used as the end time of the word at from.
+func nextBoundary(events []*SynthEvent, from int) (time.Duration, bool) {
+	for _, ev := range events[from+1:] {
+		switch ev.Type {
+		case EventEnd, EventWord, EventSentence, EventMsgTerminated:
+			return ev.AudioPosition, true
+		}
+	}
+
+	return 0, false
+}
+
+// runeIndex converts a 0-based byte offset into s to a 0-based rune index.
+func runeIndex(s string, bytePos int) int {
+	if bytePos <= 0 {
+		return 0
+	}
+	if bytePos >= len(s) {
+		return utf8.RuneCountInString(s)
+	}
+
+	return utf8.RuneCountInString(s[:bytePos])
+}
+
+func clampRuneIndex(i, max int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > max {
+		return max
+	}
+
+	return i
+}
@@ -0,0 +1,46 @@
+// +build linux
+
+package espeak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWordTimings(t *testing.T) {
+	ctx := &Context{
+		text: "café test",
+		Events: []*SynthEvent{
+			{Type: EventWord, TextPosition: 1, Length: 4, AudioPosition: 0},
+			{Type: EventWord, TextPosition: 7, Length: 4, AudioPosition: 500 * time.Millisecond},
+			{Type: EventMsgTerminated, AudioPosition: time.Second},
+		},
+	}
+
+	got := ctx.WordTimings()
+	if len(got) != 2 {
+		t.Fatalf("WordTimings() returned %d entries, want 2", len(got))
+	}
+
+	if got[0].Text != "café" || got[0].Start != 0 || got[0].End != 500*time.Millisecond {
+		t.Errorf("WordTimings()[0] = %+v", got[0])
+	}
+
+	if got[1].Text != "test" || got[1].Start != 500*time.Millisecond || got[1].End != time.Second {
+		t.Errorf("WordTimings()[1] = %+v", got[1])
+	}
+}
+
+func TestTimepoints(t *testing.T) {
+	ctx := &Context{
+		Events: []*SynthEvent{
+			{Type: EventMark, Name: "intro", AudioPosition: 250 * time.Millisecond},
+			{Type: EventWord},
+		},
+	}
+
+	got := ctx.Timepoints()
+	if len(got) != 1 || got[0].MarkName != "intro" || got[0].TimeSeconds != 0.25 {
+		t.Errorf("Timepoints() = %+v", got)
+	}
+}